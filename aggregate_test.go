@@ -0,0 +1,34 @@
+package runfx_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bfreis/runfx"
+	"go.uber.org/fx"
+)
+
+func TestRun_JoinsStopErrorWithExitCode(t *testing.T) {
+	stopErr := errors.New("boom stop")
+	opts := testFxOpts{opt: fx.Invoke(func(lc fx.Lifecycle, s fx.Shutdowner) {
+		lc.Append(fx.Hook{
+			OnStart: func(context.Context) error {
+				return s.Shutdown(runfx.ExitCode(3))
+			},
+			OnStop: func(context.Context) error {
+				return stopErr
+			},
+		})
+	})}
+
+	err := runfx.Run(context.Background(), opts)
+
+	var exitErr runfx.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode != 3 {
+		t.Fatalf("Run() error = %v, want it to join an ExitError with code 3", err)
+	}
+	if !errors.Is(err, stopErr) {
+		t.Fatalf("Run() error = %v, want it to also join %v", err, stopErr)
+	}
+}
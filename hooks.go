@@ -0,0 +1,34 @@
+package runfx
+
+import "context"
+
+// PreStarter is an interface that can be implemented by the FxOpts to run
+// logic immediately before fx.App.Start is called, outside of the DI graph.
+// This is useful for things like opening a PID file before the fx lifecycle
+// hooks run.
+type PreStarter interface {
+	PreStart(ctx context.Context) error
+}
+
+// PostStarter is an interface that can be implemented by the FxOpts to run
+// logic immediately after fx.App.Start returns successfully, outside of the
+// DI graph. This is useful for things like notifying a supervisor (e.g.
+// systemd sd_notify) that the application is ready.
+type PostStarter interface {
+	PostStart(ctx context.Context) error
+}
+
+// PreStopper is an interface that can be implemented by the FxOpts to run
+// logic immediately before fx.App.Stop is called, outside of the DI graph.
+type PreStopper interface {
+	PreStop(ctx context.Context) error
+}
+
+// PostStopper is an interface that can be implemented by the FxOpts to run
+// logic immediately after fx.App.Stop returns, outside of the DI graph. This
+// is useful for things like draining external state after the fx lifecycle
+// hooks have finished. It is not called when fx.App.Stop returns a
+// PanicError; Run then returns that PanicError alone.
+type PostStopper interface {
+	PostStop(ctx context.Context) error
+}
@@ -0,0 +1,143 @@
+package runfx_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bfreis/runfx"
+	"go.uber.org/fx"
+)
+
+// hookFxOpts lets tests opt into PreStart/PostStart/PreStop/PostStop without
+// writing a full FxOpts implementation per test.
+type hookFxOpts struct {
+	opt                                     fx.Option
+	preStart, postStart, preStop, postStop func(context.Context) error
+}
+
+func (o hookFxOpts) Fx() fx.Option { return o.opt }
+
+func (o hookFxOpts) PreStart(ctx context.Context) error {
+	if o.preStart == nil {
+		return nil
+	}
+	return o.preStart(ctx)
+}
+
+func (o hookFxOpts) PostStart(ctx context.Context) error {
+	if o.postStart == nil {
+		return nil
+	}
+	return o.postStart(ctx)
+}
+
+func (o hookFxOpts) PreStop(ctx context.Context) error {
+	if o.preStop == nil {
+		return nil
+	}
+	return o.preStop(ctx)
+}
+
+func (o hookFxOpts) PostStop(ctx context.Context) error {
+	if o.postStop == nil {
+		return nil
+	}
+	return o.postStop(ctx)
+}
+
+func TestRun_RunsLifecycleHooksAroundStartStop(t *testing.T) {
+	var order []string
+	record := func(name string) func(context.Context) error {
+		return func(context.Context) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	opts := hookFxOpts{
+		opt: fx.Invoke(func(lc fx.Lifecycle, s fx.Shutdowner) {
+			lc.Append(fx.Hook{
+				OnStart: func(context.Context) error {
+					order = append(order, "OnStart")
+					return s.Shutdown()
+				},
+				OnStop: func(context.Context) error {
+					order = append(order, "OnStop")
+					return nil
+				},
+			})
+		}),
+		preStart:  record("PreStart"),
+		postStart: record("PostStart"),
+		preStop:   record("PreStop"),
+		postStop:  record("PostStop"),
+	}
+
+	if err := runfx.Run(context.Background(), opts); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	want := []string{"PreStart", "OnStart", "PostStart", "PreStop", "OnStop", "PostStop"}
+	if len(order) != len(want) {
+		t.Fatalf("hook order = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("hook order = %v, want %v", order, want)
+		}
+	}
+}
+
+func TestRun_PostStartFailureStillStopsApp(t *testing.T) {
+	stopped := false
+	opts := hookFxOpts{
+		opt: fx.Invoke(func(lc fx.Lifecycle) {
+			lc.Append(fx.Hook{
+				OnStop: func(context.Context) error {
+					stopped = true
+					return nil
+				},
+			})
+		}),
+		postStart: func(context.Context) error {
+			return errors.New("boom")
+		},
+	}
+
+	err := runfx.Run(context.Background(), opts)
+	if err == nil {
+		t.Fatal("Run() error = nil, want non-nil")
+	}
+	if !stopped {
+		t.Fatal("Run() did not call fxApp.Stop after PostStart failed, leaking whatever OnStart opened")
+	}
+}
+
+func TestRun_PreStopFailureStillStopsApp(t *testing.T) {
+	stopped := false
+	opts := hookFxOpts{
+		opt: fx.Invoke(func(lc fx.Lifecycle, s fx.Shutdowner) {
+			lc.Append(fx.Hook{
+				OnStart: func(context.Context) error {
+					return s.Shutdown()
+				},
+				OnStop: func(context.Context) error {
+					stopped = true
+					return nil
+				},
+			})
+		}),
+		preStop: func(context.Context) error {
+			return errors.New("boom")
+		},
+	}
+
+	err := runfx.Run(context.Background(), opts)
+	if err == nil {
+		t.Fatal("Run() error = nil, want non-nil")
+	}
+	if !stopped {
+		t.Fatal("Run() did not call fxApp.Stop after PreStop failed")
+	}
+}
@@ -0,0 +1,36 @@
+package runfx_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bfreis/runfx"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
+)
+
+type captureLogger struct {
+	events []fxevent.Event
+}
+
+func (l *captureLogger) LogEvent(event fxevent.Event) {
+	l.events = append(l.events, event)
+}
+
+func TestRun_WithLoggerReceivesFxEvents(t *testing.T) {
+	var logger captureLogger
+	opts := testFxOpts{opt: fx.Invoke(func(s fx.Shutdowner) error {
+		return s.Shutdown(runfx.ExitCode(1))
+	})}
+
+	if err := runfx.Run(context.Background(), opts, runfx.WithLogger(&logger)); err == nil {
+		t.Fatal("Run() error = nil, want an ExitError")
+	}
+
+	for _, ev := range logger.events {
+		if _, ok := ev.(*fxevent.Invoked); ok {
+			return
+		}
+	}
+	t.Fatalf("logger passed to WithLogger never received fx's own Invoked event, got %#v", logger.events)
+}
@@ -0,0 +1,93 @@
+package runfx
+
+import (
+	"io"
+	"time"
+
+	"go.uber.org/fx/fxevent"
+)
+
+// Option configures the behavior of Run, RunAndExit and Validate.
+type Option func(*config)
+
+// config holds the resolved settings built up from the Options passed to
+// Run/RunAndExit/Validate.
+type config struct {
+	logger              fxevent.Logger
+	panicExitCode       int
+	startTimeout        time.Duration
+	stopTimeout         time.Duration
+	shutdownGracePeriod time.Duration
+	dotGraph            io.Writer
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{
+		panicExitCode: DefaultPanicExitCode,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithPanicExitCode overrides the exit code RunAndExit uses when Run
+// returns a PanicError. It defaults to DefaultPanicExitCode.
+func WithPanicExitCode(code int) Option {
+	return func(c *config) {
+		c.panicExitCode = code
+	}
+}
+
+// WithLogger routes runfx's own lifecycle events (SetDefaults failed,
+// validation failed, and the final error RunAndExit exits with, including
+// any exit code and signal) as well as fx's internal events (which include
+// fx's start/stop errors) through the given fxevent.Logger, instead of the standard
+// library "log" package. This lets callers plug runfx into the same
+// structured logging sink their fx application already uses, e.g. by
+// passing the fxevent.Logger built from their zap or slog logger.
+func WithLogger(logger fxevent.Logger) Option {
+	return func(c *config) {
+		c.logger = logger
+	}
+}
+
+// WithStartTimeout overrides the timeout applied to fx.App.Start, taking
+// precedence over the fx.StartTimeout option (if any) configured in
+// FxOpts.Fx(). This lets deployment concerns like a k8s startup probe
+// deadline be set at the call site, independent of the DI graph.
+func WithStartTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.startTimeout = d
+	}
+}
+
+// WithStopTimeout overrides the timeout applied to fx.App.Stop, taking
+// precedence over the fx.StopTimeout option (if any) configured in
+// FxOpts.Fx(). This lets deployment concerns like k8s
+// terminationGracePeriodSeconds or systemd's TimeoutStopSec be set at the
+// call site, independent of the DI graph.
+func WithStopTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.stopTimeout = d
+	}
+}
+
+// WithShutdownGracePeriod bounds the total time Run spends on fx.App.Stop
+// once a shutdown signal has been received, regardless of the start/stop
+// timeouts above. When it elapses, the stop context is forcibly cancelled.
+// A zero value (the default) means no additional bound is applied.
+func WithShutdownGracePeriod(d time.Duration) Option {
+	return func(c *config) {
+		c.shutdownGracePeriod = d
+	}
+}
+
+// WithDotGraph makes Validate write the fx dependency graph, in Graphviz
+// DOT format, to w after the graph is successfully built. It has no effect
+// on Run/RunAndExit.
+func WithDotGraph(w io.Writer) Option {
+	return func(c *config) {
+		c.dotGraph = w
+	}
+}
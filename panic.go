@@ -0,0 +1,58 @@
+package runfx
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"go.uber.org/fx"
+)
+
+// DefaultPanicExitCode is the exit code RunAndExit uses when Run returns a
+// PanicError and no WithPanicExitCode option overrode it. It matches the
+// exit code the Go runtime itself uses for an unrecovered panic.
+const DefaultPanicExitCode = 2
+
+// PanicError is returned by Run when a panic is recovered from an OnStart or
+// OnStop lifecycle hook, instead of letting the panic crash the goroutine fx
+// runs that hook on.
+type PanicError struct {
+	// Recovered is the value passed to panic().
+	Recovered any
+	// Stack is the stack trace captured at the point of recovery.
+	Stack []byte
+}
+
+func (e PanicError) Error() string {
+	return fmt.Sprintf("panic: %v\n%s", e.Recovered, e.Stack)
+}
+
+// recoveringLifecycle wraps an fx.Lifecycle so that every hook appended to
+// it recovers its own panics as a PanicError, instead of letting them crash
+// the goroutine fx runs OnStart/OnStop hooks on (fx.App.Start/Stop run hooks
+// on an internal goroutine of their own, so a recover() around the call to
+// Start/Stop can never see a panic thrown inside a hook).
+type recoveringLifecycle struct {
+	fx.Lifecycle
+}
+
+func (l recoveringLifecycle) Append(hook fx.Hook) {
+	l.Lifecycle.Append(fx.Hook{
+		OnStart: recoverHook(hook.OnStart),
+		OnStop:  recoverHook(hook.OnStop),
+	})
+}
+
+func recoverHook(fn func(context.Context) error) func(context.Context) error {
+	if fn == nil {
+		return nil
+	}
+	return func(ctx context.Context) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = PanicError{Recovered: r, Stack: debug.Stack()}
+			}
+		}()
+		return fn(ctx)
+	}
+}
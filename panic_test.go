@@ -0,0 +1,61 @@
+package runfx_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bfreis/runfx"
+	"go.uber.org/fx"
+)
+
+type testFxOpts struct {
+	opt fx.Option
+}
+
+func (o testFxOpts) Fx() fx.Option { return o.opt }
+
+func TestRun_RecoversPanicInOnStart(t *testing.T) {
+	opts := testFxOpts{opt: fx.Invoke(func(lc fx.Lifecycle) {
+		lc.Append(fx.Hook{
+			OnStart: func(context.Context) error {
+				panic("boom")
+			},
+		})
+	})}
+
+	err := runfx.Run(context.Background(), opts)
+
+	var panicErr runfx.PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("Run() error = %v, want a PanicError", err)
+	}
+	if panicErr.Recovered != "boom" {
+		t.Fatalf("PanicError.Recovered = %v, want %q", panicErr.Recovered, "boom")
+	}
+}
+
+func TestRun_RecoversPanicInOnStop(t *testing.T) {
+	// Trigger shutdown from OnStart (buffered pre-start, replayed once Start
+	// returns) so Run proceeds to Stop, where the panic is thrown.
+	opts := testFxOpts{opt: fx.Invoke(func(lc fx.Lifecycle, s fx.Shutdowner) {
+		lc.Append(fx.Hook{
+			OnStart: func(context.Context) error {
+				return s.Shutdown()
+			},
+			OnStop: func(context.Context) error {
+				panic("boom")
+			},
+		})
+	})}
+
+	err := runfx.Run(context.Background(), opts)
+
+	var panicErr runfx.PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("Run() error = %v, want a PanicError", err)
+	}
+	if panicErr.Recovered != "boom" {
+		t.Fatalf("PanicError.Recovered = %v, want %q", panicErr.Recovered, "boom")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 
 	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
 )
 
 type FxOpts interface {
@@ -17,17 +18,41 @@ type FxOpts interface {
 // RunAndExit runs the application and exits with the appropriate exit code.
 // It does not return.
 // The given context is used to start and stop the application.
-func RunAndExit(ctx context.Context, fxOpts FxOpts) {
-	err := Run(ctx, fxOpts)
+func RunAndExit(ctx context.Context, fxOpts FxOpts, opts ...Option) {
+	cfg := newConfig(opts)
+
+	err := run(ctx, fxOpts, cfg)
 	if err != nil {
+		// The LogEvent(&fxevent.Stopped{...}) calls below deliberately reuse
+		// fx's own terminal event, even for failures (a panic, a PreStart
+		// error) that happen before fx.App.Stop, or even fx.App.Start, ever
+		// runs. fx defines no "failed before starting" event of its own, and
+		// routing these anywhere else would mean every fxevent.Logger this
+		// package is handed needs its own special case to avoid dropping
+		// them; Stopped is the closest fit for "the app is done, here's why."
 		// retrieve exit code from err
 		var exitErr ExitError
 		if ok := errors.As(err, &exitErr); ok {
-			log.Printf("exit: code=%d signal=%s", exitErr.ExitCode, exitErr.Signal)
+			logExit(cfg, err)
 			os.Exit(exitErr.ExitCode)
 		}
 
-		log.Fatal(err)
+		var panicErr PanicError
+		if ok := errors.As(err, &panicErr); ok {
+			if cfg.logger != nil {
+				cfg.logger.LogEvent(&fxevent.Stopped{Err: panicErr})
+			} else {
+				log.Print(panicErr)
+			}
+			os.Exit(cfg.panicExitCode)
+		}
+
+		if cfg.logger != nil {
+			cfg.logger.LogEvent(&fxevent.Stopped{Err: err})
+		} else {
+			log.Fatal(err)
+		}
+		os.Exit(1)
 	}
 
 	os.Exit(0)
@@ -37,52 +62,141 @@ func RunAndExit(ctx context.Context, fxOpts FxOpts) {
 // any of the steps: settings defaults, validation, starting the fx app,
 // stopping the fx app, or any exit code from the fx app.
 // The given context is used to start and stop the application.
-func Run(ctx context.Context, fxOpts FxOpts) error {
-	if defSetter, ok := fxOpts.(SetDefaulter); ok {
-		err := defSetter.SetDefaults()
-		if err != nil {
-			return fmt.Errorf("set defaults: %w", err)
-		}
+func Run(ctx context.Context, fxOpts FxOpts, opts ...Option) error {
+	return run(ctx, fxOpts, newConfig(opts))
+}
+
+// logExit logs err in full, not just exitErr, so that any other error joined
+// alongside it (e.g. an fx.Stop failure during a signal-driven shutdown)
+// isn't silently dropped. Like the other LogEvent calls in RunAndExit, it
+// reuses fxevent.Stopped as the generic "terminal failure" event.
+func logExit(cfg *config, err error) {
+	if cfg.logger != nil {
+		cfg.logger.LogEvent(&fxevent.Stopped{Err: err})
+		return
 	}
+	log.Printf("exit: %v", err)
+}
 
-	if validator, ok := fxOpts.(Validator); ok {
-		err := validator.Validate()
-		if err != nil {
-			return fmt.Errorf("validate: %w", err)
-		}
+func run(ctx context.Context, fxOpts FxOpts, cfg *config) error {
+	if err := setDefaultsAndValidate(fxOpts, cfg); err != nil {
+		return err
 	}
 
-	fxApp := fx.New(fxOpts.Fx())
+	eventLogger := cfg.logger
+	if eventLogger == nil {
+		eventLogger = &fxevent.ConsoleLogger{W: os.Stderr}
+	}
+
+	var shutdowner bufferedShutdowner
+	fxOptions := fx.Options(
+		fxOpts.Fx(),
+		fx.Decorate(func(real fx.Shutdowner) fx.Shutdowner {
+			shutdowner.setReal(real)
+			return &shutdowner
+		}),
+		fx.Provide(func(s fx.Shutdowner) Shutdowner {
+			return s.(Shutdowner)
+		}),
+		fx.Decorate(func(lc fx.Lifecycle) fx.Lifecycle {
+			return recoveringLifecycle{Lifecycle: lc}
+		}),
+		fx.WithLogger(func() fxevent.Logger { return eventLogger }),
+	)
+
+	fxApp := fx.New(fxOptions)
 	if fxApp.Err() != nil {
 		return fmt.Errorf("fx.New: %w", fxApp.Err())
 	}
 
-	startCtx, startCancel := context.WithTimeout(ctx, fxApp.StartTimeout())
+	startTimeout := cfg.startTimeout
+	if startTimeout == 0 {
+		startTimeout = fxApp.StartTimeout()
+	}
+	startCtx, startCancel := context.WithTimeout(ctx, startTimeout)
 	defer startCancel()
 
+	if preStarter, ok := fxOpts.(PreStarter); ok {
+		if err := preStarter.PreStart(startCtx); err != nil {
+			return fmt.Errorf("pre start: %w", err)
+		}
+	}
+
 	err := fxApp.Start(startCtx)
+	var panicErr PanicError
+	if errors.As(err, &panicErr) {
+		return panicErr
+	}
 	if err != nil {
 		return fmt.Errorf("fx.Start: %w", err)
 	}
 
-	sig := <-fxApp.Wait()
+	// fxApp.Start has succeeded, so every failure from here on must still
+	// fall through to fxApp.Stop below instead of returning directly, or the
+	// registered OnStop hooks never run and whatever OnStart opened leaks.
+	var errs []error
+
+	if postStarter, ok := fxOpts.(PostStarter); ok {
+		if err := postStarter.PostStart(startCtx); err != nil {
+			errs = append(errs, fmt.Errorf("post start: %w", err))
+		}
+	}
+
+	if err := shutdowner.replayPending(); err != nil {
+		errs = append(errs, err)
+	}
+
+	var sig fx.ShutdownSignal
+	if len(errs) == 0 {
+		sig = <-fxApp.Wait()
+	}
 
-	stopCtx, stopCancel := context.WithTimeout(ctx, fxApp.StopTimeout())
+	stopParentCtx := ctx
+	if cfg.shutdownGracePeriod > 0 {
+		var graceCancel context.CancelFunc
+		stopParentCtx, graceCancel = context.WithTimeout(ctx, cfg.shutdownGracePeriod)
+		defer graceCancel()
+	}
+
+	stopTimeout := cfg.stopTimeout
+	if stopTimeout == 0 {
+		stopTimeout = fxApp.StopTimeout()
+	}
+	stopCtx, stopCancel := context.WithTimeout(stopParentCtx, stopTimeout)
 	defer stopCancel()
 
+	if preStopper, ok := fxOpts.(PreStopper); ok {
+		if err := preStopper.PreStop(stopCtx); err != nil {
+			errs = append(errs, fmt.Errorf("pre stop: %w", err))
+		}
+	}
+
 	err = fxApp.Stop(stopCtx)
+	if errors.As(err, &panicErr) {
+		return panicErr
+	}
 	if err != nil {
-		return fmt.Errorf("fx.Stop: %w", err)
+		errs = append(errs, fmt.Errorf("fx.Stop: %w", err))
+	}
+
+	if postStopper, ok := fxOpts.(PostStopper); ok {
+		if err := postStopper.PostStop(stopCtx); err != nil {
+			errs = append(errs, fmt.Errorf("post stop: %w", err))
+		}
 	}
 
 	if sig.ExitCode != 0 {
-		return ExitError{
+		errs = append(errs, ExitError{
 			ExitCode: sig.ExitCode,
 			Signal:   sig.Signal,
-		}
+		})
+	}
+
+	if shutdownErr := shutdowner.takeError(); shutdownErr != nil {
+		errs = append(errs, shutdownErr)
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
 // SetDefaulter is an interface that can be implemented by the FxOpts
@@ -0,0 +1,104 @@
+package runfx
+
+import (
+	"fmt"
+	"sync"
+
+	"go.uber.org/fx"
+)
+
+// Shutdowner extends fx.Shutdowner with ShutdownWithError, so that callers
+// can depend on it from their fx.Invoke/fx.Provide functions without
+// importing go.uber.org/fx directly. Calling Shutdown(runfx.ExitCode(n))
+// from anywhere in the graph makes RunAndExit exit with code n.
+type Shutdowner interface {
+	fx.Shutdowner
+
+	// ShutdownWithError behaves like Shutdown, but additionally attaches err
+	// to be joined into the error Run returns, mirroring how Shutdown's
+	// fx.ExitCode option attaches an exit code to the ShutdownSignal.
+	ShutdownWithError(err error, opts ...ShutdownOption) error
+}
+
+// ShutdownOption re-exports fx.ShutdownOption.
+type ShutdownOption = fx.ShutdownOption
+
+// ExitCode re-exports fx.ExitCode, letting callers request a specific
+// process exit code from a Shutdowner.Shutdown call.
+func ExitCode(code int) ShutdownOption {
+	return fx.ExitCode(code)
+}
+
+// bufferedShutdowner wraps the real fx.Shutdowner to work around the known
+// upstream race where a shutdown requested before fx.App.Start returns can
+// be lost (the last signal is nil'd out once Start completes). Requests
+// made while started is false are buffered and replayed once run() marks
+// the app as started.
+type bufferedShutdowner struct {
+	mu          sync.Mutex
+	real        fx.Shutdowner
+	started     bool
+	pending     []ShutdownOption
+	buffered    bool
+	shutdownErr error
+}
+
+func (b *bufferedShutdowner) setReal(real fx.Shutdowner) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.real = real
+}
+
+func (b *bufferedShutdowner) Shutdown(opts ...ShutdownOption) error {
+	b.mu.Lock()
+	if !b.started {
+		b.pending = opts
+		b.buffered = true
+		b.mu.Unlock()
+		return nil
+	}
+	real := b.real
+	b.mu.Unlock()
+	return real.Shutdown(opts...)
+}
+
+// ShutdownWithError records err to be joined into the error Run returns,
+// then forwards to Shutdown.
+func (b *bufferedShutdowner) ShutdownWithError(err error, opts ...ShutdownOption) error {
+	b.mu.Lock()
+	b.shutdownErr = err
+	b.mu.Unlock()
+	return b.Shutdown(opts...)
+}
+
+// takeError returns the error attached via ShutdownWithError, if any.
+func (b *bufferedShutdowner) takeError() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.shutdownErr
+}
+
+// markStarted marks the app as started and returns any shutdown request
+// that was buffered while it wasn't, so the caller can replay it now that
+// it's safe to do so.
+func (b *bufferedShutdowner) markStarted() (pending []ShutdownOption, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.started = true
+	if !b.buffered {
+		return nil, false
+	}
+	b.buffered = false
+	return b.pending, true
+}
+
+func (b *bufferedShutdowner) replayPending() error {
+	pending, ok := b.markStarted()
+	if !ok {
+		return nil
+	}
+	if err := b.real.Shutdown(pending...); err != nil {
+		return fmt.Errorf("fx.Shutdown (buffered pre-start request): %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package runfx
+
+import (
+	"errors"
+	"testing"
+
+	"go.uber.org/fx"
+)
+
+type fakeShutdowner struct {
+	err error
+}
+
+func (f fakeShutdowner) Shutdown(opts ...fx.ShutdownOption) error {
+	return f.err
+}
+
+func TestBufferedShutdowner_ReplayPendingPropagatesError(t *testing.T) {
+	wantErr := errors.New("boom")
+	var b bufferedShutdowner
+	b.setReal(fakeShutdowner{err: wantErr})
+
+	// Buffer a request (started is still false), then mark started and
+	// replay it, the same sequence run() drives around fxApp.Start.
+	if err := b.Shutdown(); err != nil {
+		t.Fatalf("Shutdown() (buffered) error = %v, want nil", err)
+	}
+
+	err := b.replayPending()
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("replayPending() error = %v, want it to wrap %v", err, wantErr)
+	}
+}
@@ -0,0 +1,52 @@
+package runfx_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bfreis/runfx"
+	"go.uber.org/fx"
+)
+
+func TestRun_ShutdownerExitCode(t *testing.T) {
+	// Shutdowner.Shutdown is injected into, and called directly from, a
+	// plain fx.Invoke function — not wired through a lifecycle hook at all.
+	opts := testFxOpts{opt: fx.Invoke(func(s fx.Shutdowner) error {
+		return s.Shutdown(runfx.ExitCode(42))
+	})}
+
+	err := runfx.Run(context.Background(), opts)
+
+	var exitErr runfx.ExitError
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("Run() error = %v, want an ExitError", err)
+	}
+	if exitErr.ExitCode != 42 {
+		t.Fatalf("ExitError.ExitCode = %d, want 42", exitErr.ExitCode)
+	}
+}
+
+func TestRun_BuffersShutdownRequestedBeforeStartReturns(t *testing.T) {
+	// Shutdowner.Shutdown is called synchronously from within OnStart, i.e.
+	// strictly before fx.App.Start returns. Without buffering, this is the
+	// known upstream race where the signal can be lost; Run should still
+	// observe it and return the requested exit code.
+	opts := testFxOpts{opt: fx.Invoke(func(lc fx.Lifecycle, s fx.Shutdowner) {
+		lc.Append(fx.Hook{
+			OnStart: func(context.Context) error {
+				return s.Shutdown(runfx.ExitCode(7))
+			},
+		})
+	})}
+
+	err := runfx.Run(context.Background(), opts)
+
+	var exitErr runfx.ExitError
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("Run() error = %v, want an ExitError", err)
+	}
+	if exitErr.ExitCode != 7 {
+		t.Fatalf("ExitError.ExitCode = %d, want 7", exitErr.ExitCode)
+	}
+}
@@ -0,0 +1,72 @@
+package runfx_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bfreis/runfx"
+	"go.uber.org/fx"
+)
+
+func TestRun_WithStartTimeout(t *testing.T) {
+	opts := testFxOpts{opt: fx.Invoke(func(lc fx.Lifecycle) {
+		lc.Append(fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		})
+	})}
+
+	err := runfx.Run(context.Background(), opts, runfx.WithStartTimeout(10*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Run() error = %v, want it to wrap context.DeadlineExceeded", err)
+	}
+}
+
+func TestRun_WithStopTimeout(t *testing.T) {
+	opts := testFxOpts{opt: fx.Invoke(func(lc fx.Lifecycle, s fx.Shutdowner) {
+		lc.Append(fx.Hook{
+			OnStart: func(context.Context) error {
+				return s.Shutdown()
+			},
+			OnStop: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		})
+	})}
+
+	err := runfx.Run(context.Background(), opts, runfx.WithStopTimeout(10*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Run() error = %v, want it to wrap context.DeadlineExceeded", err)
+	}
+}
+
+func TestRun_WithShutdownGracePeriod(t *testing.T) {
+	// A generous WithStopTimeout would normally let OnStop run for an hour;
+	// WithShutdownGracePeriod must still cut it short.
+	opts := testFxOpts{opt: fx.Invoke(func(lc fx.Lifecycle, s fx.Shutdowner) {
+		lc.Append(fx.Hook{
+			OnStart: func(context.Context) error {
+				return s.Shutdown()
+			},
+			OnStop: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		})
+	})}
+
+	err := runfx.Run(
+		context.Background(),
+		opts,
+		runfx.WithStopTimeout(time.Hour),
+		runfx.WithShutdownGracePeriod(10*time.Millisecond),
+	)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Run() error = %v, want it to wrap context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,94 @@
+package runfx
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
+)
+
+// Validate runs SetDefaults and Validate on fxOpts (if implemented) and then
+// builds the fx dependency graph with fx.New, without calling Start. This
+// verifies the dependency graph, decorators, and provided constructors, and
+// is valuable in CI to catch DI errors and missing config before deploy. Use
+// WithDotGraph to additionally dump the graph in Graphviz format. ctx bounds
+// how long graph construction (which runs all eager fx.Invoke calls) may
+// take; it plays the same role here as the start/stop timeouts play in Run.
+func Validate(ctx context.Context, fxOpts FxOpts, opts ...Option) error {
+	cfg := newConfig(opts)
+
+	if err := setDefaultsAndValidate(fxOpts, cfg); err != nil {
+		return err
+	}
+
+	var dot fx.DotGraph
+	var shutdowner bufferedShutdowner
+	fxOptions := fx.Options(
+		fxOpts.Fx(),
+		fx.Decorate(func(real fx.Shutdowner) fx.Shutdowner {
+			shutdowner.setReal(real)
+			return &shutdowner
+		}),
+		fx.Provide(func(s fx.Shutdowner) Shutdowner {
+			return s.(Shutdowner)
+		}),
+	)
+	if cfg.logger != nil {
+		fxOptions = fx.Options(fxOptions, fx.WithLogger(func() fxevent.Logger { return cfg.logger }))
+	}
+	if cfg.dotGraph != nil {
+		fxOptions = fx.Options(fxOptions, fx.Populate(&dot))
+	}
+
+	fxAppCh := make(chan *fx.App, 1)
+	go func() { fxAppCh <- fx.New(fxOptions) }()
+
+	var fxApp *fx.App
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case fxApp = <-fxAppCh:
+	}
+
+	if fxApp.Err() != nil {
+		return fmt.Errorf("fx.New: %w", fxApp.Err())
+	}
+
+	if cfg.dotGraph != nil {
+		if _, err := io.WriteString(cfg.dotGraph, string(dot)); err != nil {
+			return fmt.Errorf("write dot graph: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// setDefaultsAndValidate runs SetDefaults and Validate on fxOpts, if
+// implemented, logging and wrapping any error the same way for both Run and
+// Validate. The LogEvent calls reuse fxevent.Invoked, fx's event for an
+// fx.Invoke call returning an error, as the closest fit for "a function this
+// package invoked failed" even though SetDefaults/Validate aren't wired
+// through fx.Invoke.
+func setDefaultsAndValidate(fxOpts FxOpts, cfg *config) error {
+	if defSetter, ok := fxOpts.(SetDefaulter); ok {
+		if err := defSetter.SetDefaults(); err != nil {
+			if cfg.logger != nil {
+				cfg.logger.LogEvent(&fxevent.Invoked{FunctionName: "SetDefaults", Err: err})
+			}
+			return fmt.Errorf("set defaults: %w", err)
+		}
+	}
+
+	if validator, ok := fxOpts.(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			if cfg.logger != nil {
+				cfg.logger.LogEvent(&fxevent.Invoked{FunctionName: "Validate", Err: err})
+			}
+			return fmt.Errorf("validate: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package runfx_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/bfreis/runfx"
+	"go.uber.org/fx"
+)
+
+func TestValidate_BuildsGraphWithoutStarting(t *testing.T) {
+	started := false
+	opts := testFxOpts{opt: fx.Invoke(func(lc fx.Lifecycle) {
+		lc.Append(fx.Hook{
+			OnStart: func(context.Context) error {
+				started = true
+				return nil
+			},
+		})
+	})}
+
+	if err := runfx.Validate(context.Background(), opts); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if started {
+		t.Fatal("Validate() must not run OnStart hooks")
+	}
+}
+
+func TestValidate_WithDotGraph(t *testing.T) {
+	var buf bytes.Buffer
+	opts := testFxOpts{opt: fx.Provide(func() int { return 42 })}
+
+	err := runfx.Validate(context.Background(), opts, runfx.WithDotGraph(&buf))
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "digraph") {
+		t.Fatalf("expected a Graphviz digraph, got %q", buf.String())
+	}
+}